@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Hand-written placeholder for the StreamReadArtifact/StreamWriteArtifact
+// streaming types, standing in for the real protoc-gen-go/
+// protoc-gen-go-grpc bindings until backend/api/v2beta1/run.proto grows
+// these RPCs and `make generate` can produce them.
+
+package go_client
+
+import "google.golang.org/grpc"
+
+// ArtifactBlock is one fixed-size, checksummed chunk of a
+// StreamWriteArtifact upload.
+type ArtifactBlock struct {
+	Index  int32
+	Sha256 string
+	Data   []byte
+}
+
+func (b *ArtifactBlock) GetIndex() int32 {
+	if b == nil {
+		return 0
+	}
+	return b.Index
+}
+
+func (b *ArtifactBlock) GetSha256() string {
+	if b == nil {
+		return ""
+	}
+	return b.Sha256
+}
+
+func (b *ArtifactBlock) GetData() []byte {
+	if b == nil {
+		return nil
+	}
+	return b.Data
+}
+
+// ReadArtifactChunk is one message of a StreamReadArtifact response: a
+// proxied chunk of bytes, or (exactly once, as the only message) a
+// signed direct-download URL.
+type ReadArtifactChunk struct {
+	Data      []byte
+	SignedUrl string
+}
+
+// CommitArtifactUpload signals that the client has sent every block and
+// the server should assemble them into the backing object store.
+type CommitArtifactUpload struct{}
+
+// WriteArtifactRequest is one message of a StreamWriteArtifact upload.
+// The first message on a new session must carry RunId/NodeId/
+// ArtifactName; a resumed session instead carries SessionToken.
+type WriteArtifactRequest struct {
+	RunId        string
+	NodeId       string
+	ArtifactName string
+	SessionToken string
+	Payload      isWriteArtifactRequest_Payload
+}
+
+type isWriteArtifactRequest_Payload interface {
+	isWriteArtifactRequest_Payload()
+}
+
+type WriteArtifactRequest_Block struct {
+	Block *ArtifactBlock
+}
+
+type WriteArtifactRequest_Commit struct {
+	Commit *CommitArtifactUpload
+}
+
+func (*WriteArtifactRequest_Block) isWriteArtifactRequest_Payload()  {}
+func (*WriteArtifactRequest_Commit) isWriteArtifactRequest_Payload() {}
+
+func (r *WriteArtifactRequest) GetRunId() string {
+	if r == nil {
+		return ""
+	}
+	return r.RunId
+}
+
+func (r *WriteArtifactRequest) GetNodeId() string {
+	if r == nil {
+		return ""
+	}
+	return r.NodeId
+}
+
+func (r *WriteArtifactRequest) GetArtifactName() string {
+	if r == nil {
+		return ""
+	}
+	return r.ArtifactName
+}
+
+func (r *WriteArtifactRequest) GetSessionToken() string {
+	if r == nil {
+		return ""
+	}
+	return r.SessionToken
+}
+
+func (r *WriteArtifactRequest) GetPayload() isWriteArtifactRequest_Payload {
+	if r == nil {
+		return nil
+	}
+	return r.Payload
+}
+
+type WriteArtifactResponse struct {
+	SessionToken string
+}
+
+// RunService_StreamReadArtifactServer is the server-side stream handle
+// for StreamReadArtifact, shaped the way protoc-gen-go-grpc generates
+// server-streaming RPCs.
+type RunService_StreamReadArtifactServer interface {
+	Send(*ReadArtifactChunk) error
+	grpc.ServerStream
+}
+
+// RunService_StreamWriteArtifactServer is the server-side stream handle
+// for StreamWriteArtifact, shaped the way protoc-gen-go-grpc generates
+// client-streaming RPCs.
+type RunService_StreamWriteArtifactServer interface {
+	SendAndClose(*WriteArtifactResponse) error
+	Recv() (*WriteArtifactRequest, error)
+	grpc.ServerStream
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Hand-written placeholder for the bulk-authorization API types,
+// standing in for the real protoc-gen-go bindings until
+// backend/api/v2beta1/authorization.proto exists and `make generate` can
+// produce them.
+
+package go_client
+
+// VerbDecision is the authorization outcome for a single verb.
+type VerbDecision struct {
+	Verb    string
+	Allowed bool
+}
+
+type ListAccessibleRunsRequest struct {
+	Verbs     []string
+	PageToken string
+	PageSize  int32
+	SortBy    string
+}
+
+type ListAccessibleRunsResponse struct {
+	Runs            []*Run
+	TotalSize       int32
+	NextPageToken   string
+	NamespaceAccess []*ListAccessibleRunsResponse_NamespaceDecisions
+}
+
+type ListAccessibleRunsResponse_NamespaceDecisions struct {
+	Namespace string
+	Decisions []*VerbDecision
+}
+
+type GetRunPermissionsRequest struct {
+	RunId string
+}
+
+func (r *GetRunPermissionsRequest) GetRunId() string {
+	if r == nil {
+		return ""
+	}
+	return r.RunId
+}
+
+type GetRunPermissionsResponse struct {
+	Permissions []*GetRunPermissionsResponse_Permission
+}
+
+type GetRunPermissionsResponse_Permission struct {
+	Verb    string
+	Allowed bool
+	Reason  string
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Hand-written placeholder for the custom task registration API types,
+// standing in for the real protoc-gen-go bindings until
+// backend/api/v2beta1/custom_task.proto exists and `make generate` can
+// produce them.
+
+package go_client
+
+// CustomTaskType is a {namespace, group, kind} triple an operator has
+// whitelisted for CustomTaskRef runs.
+type CustomTaskType struct {
+	Namespace string
+	Group     string
+	Kind      string
+}
+
+type RegisterCustomTaskTypeRequest struct {
+	Namespace string
+	Group     string
+	Kind      string
+}
+
+type UnregisterCustomTaskTypeRequest struct {
+	Namespace string
+	Group     string
+	Kind      string
+}
+
+type ListCustomTaskTypesRequest struct {
+	Namespace string
+}
+
+type ListCustomTaskTypesResponse struct {
+	CustomTaskTypes []*CustomTaskType
+}
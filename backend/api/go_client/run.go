@@ -0,0 +1,264 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Hand-written placeholder for the Run API types, standing in for the
+// real protoc-gen-go bindings until backend/api/v2beta1/run.proto exists
+// and `make generate` can produce them. These structs intentionally have
+// no wire tags, registration, or proto.Message methods.
+
+// Package go_client holds the apiserver's gRPC/REST API types. Once the
+// backend/api/v2beta1/*.proto definitions this package stands in for are
+// added, its contents should be replaced by `make generate`'s output.
+package go_client
+
+// ResourceKey identifies a single resource (namespace, experiment, ...)
+// that a ResourceReference points at.
+type ResourceKey struct {
+	Type string
+	Id   string
+}
+
+// ResourceReference associates a resource (e.g. a Run) with another
+// resource it belongs to (e.g. its owning Experiment or Namespace).
+type ResourceReference struct {
+	Key          *ResourceKey
+	Relationship string
+}
+
+// PipelineSpec is the compiled Argo Workflow manifest (or a reference to
+// a stored pipeline version) a run is created from.
+type PipelineSpec struct {
+	PipelineId       string
+	WorkflowManifest string
+}
+
+// CustomTaskRef identifies the arbitrary, non-Argo controller a run
+// should be delegated to, analogous to Tekton's Run CRD.
+type CustomTaskRef struct {
+	ApiVersion string
+	Kind       string
+	Name       string
+}
+
+func (c *CustomTaskRef) GetApiVersion() string {
+	if c == nil {
+		return ""
+	}
+	return c.ApiVersion
+}
+
+func (c *CustomTaskRef) GetKind() string {
+	if c == nil {
+		return ""
+	}
+	return c.Kind
+}
+
+func (c *CustomTaskRef) GetName() string {
+	if c == nil {
+		return ""
+	}
+	return c.Name
+}
+
+// Run is the user-facing representation of a single pipeline run.
+type Run struct {
+	Id                 string
+	Name               string
+	Namespace          string
+	ServiceAccount     string
+	PipelineSpec       *PipelineSpec
+	ResourceReferences []*ResourceReference
+	CustomTaskRef      *CustomTaskRef
+}
+
+func (r *Run) GetName() string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
+
+func (r *Run) GetNamespace() string {
+	if r == nil {
+		return ""
+	}
+	return r.Namespace
+}
+
+func (r *Run) GetServiceAccount() string {
+	if r == nil {
+		return ""
+	}
+	return r.ServiceAccount
+}
+
+func (r *Run) GetCustomTaskRef() *CustomTaskRef {
+	if r == nil {
+		return nil
+	}
+	return r.CustomTaskRef
+}
+
+// RunDetail is a Run plus the runtime state surfaced by GetRun/ListRuns.
+type RunDetail struct {
+	Run *Run
+}
+
+type CreateRunRequest struct {
+	Run *Run
+}
+
+type GetRunRequest struct {
+	RunId string
+}
+
+func (r *GetRunRequest) GetRunId() string {
+	if r == nil {
+		return ""
+	}
+	return r.RunId
+}
+
+type ListRunsRequest struct {
+	PageToken            string
+	PageSize             int32
+	SortBy               string
+	Filter               string
+	ResourceReferenceKey *ResourceKey
+}
+
+type ListRunsResponse struct {
+	Runs          []*Run
+	TotalSize     int32
+	NextPageToken string
+}
+
+type ArchiveRunRequest struct {
+	Id string
+}
+
+type UnarchiveRunRequest struct {
+	Id string
+}
+
+type DeleteRunRequest struct {
+	Id string
+}
+
+type TerminateRunRequest struct {
+	RunId string
+}
+
+type RetryRunRequest struct {
+	RunId string
+}
+
+// RunMetric is a single named metric reported against a run node.
+type RunMetric struct {
+	Name   string
+	NodeId string
+	Value  float64
+}
+
+func (m *RunMetric) GetName() string {
+	if m == nil {
+		return ""
+	}
+	return m.Name
+}
+
+func (m *RunMetric) GetNodeId() string {
+	if m == nil {
+		return ""
+	}
+	return m.NodeId
+}
+
+type ReportRunMetricsRequest struct {
+	RunId   string
+	Metrics []*RunMetric
+}
+
+func (r *ReportRunMetricsRequest) GetRunId() string {
+	if r == nil {
+		return ""
+	}
+	return r.RunId
+}
+
+func (r *ReportRunMetricsRequest) GetMetrics() []*RunMetric {
+	if r == nil {
+		return nil
+	}
+	return r.Metrics
+}
+
+type ReportRunMetricsResponse struct {
+	Results []*ReportRunMetricsResponse_ReportRunMetricResult
+}
+
+type ReportRunMetricsResponse_ReportRunMetricResult struct {
+	MetricName   string
+	MetricNodeId string
+	Status       string
+	Message      string
+}
+
+type ReadArtifactRequest struct {
+	RunId        string
+	NodeId       string
+	ArtifactName string
+	RangeStart   int64
+	RangeEnd     int64
+}
+
+func (r *ReadArtifactRequest) GetRunId() string {
+	if r == nil {
+		return ""
+	}
+	return r.RunId
+}
+
+func (r *ReadArtifactRequest) GetNodeId() string {
+	if r == nil {
+		return ""
+	}
+	return r.NodeId
+}
+
+func (r *ReadArtifactRequest) GetArtifactName() string {
+	if r == nil {
+		return ""
+	}
+	return r.ArtifactName
+}
+
+func (r *ReadArtifactRequest) GetRangeStart() int64 {
+	if r == nil {
+		return 0
+	}
+	return r.RangeStart
+}
+
+func (r *ReadArtifactRequest) GetRangeEnd() int64 {
+	if r == nil {
+		return 0
+	}
+	return r.RangeEnd
+}
+
+type ReadArtifactResponse struct {
+	Data []byte
+}
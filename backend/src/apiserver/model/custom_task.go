@@ -0,0 +1,52 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "strings"
+
+// CustomTaskRef identifies an arbitrary, non-Argo controller that should
+// own a run, analogous to Tekton's Run CRD. A run that carries a
+// CustomTaskRef is delegated to that controller instead of being
+// translated into an Argo Workflow.
+type CustomTaskRef struct {
+	// APIVersion is the group/version of the custom controller's CRD, e.g.
+	// "custom.tekton.dev/v1alpha1".
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Kind is the CRD kind, e.g. "PythonTask".
+	Kind string `json:"kind,omitempty"`
+	// Name identifies the specific custom task template to run.
+	Name string `json:"name,omitempty"`
+}
+
+// CustomTaskRegistration whitelists a {group, kind} pair that operators
+// have approved for use within a namespace. CreateRun rejects any
+// CustomTaskRef whose group/kind is not registered for the run's
+// namespace.
+type CustomTaskRegistration struct {
+	Namespace string `gorm:"column:Namespace; not null; primary_key"`
+	Group     string `gorm:"column:Group; not null; primary_key"`
+	Kind      string `gorm:"column:Kind; not null; primary_key"`
+}
+
+// CustomTaskGroup extracts the API group from a Kubernetes-style
+// "group/version" apiVersion string (e.g. "custom.tekton.dev/v1alpha1"
+// -> "custom.tekton.dev"), the same group a CustomTaskRegistration is
+// keyed on.
+func CustomTaskGroup(apiVersion string) string {
+	if idx := strings.LastIndex(apiVersion, "/"); idx != -1 {
+		return apiVersion[:idx]
+	}
+	return apiVersion
+}
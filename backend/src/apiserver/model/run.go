@@ -0,0 +1,31 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Run is the persisted record for a single pipeline run, whether backed
+// by an Argo Workflow or a delegated CustomTaskRef.
+type Run struct {
+	UUID           string `gorm:"column:UUID; not null; primary_key"`
+	Name           string `gorm:"column:Name; not null"`
+	Namespace      string `gorm:"column:Namespace; not null"`
+	ServiceAccount string `gorm:"column:ServiceAccount; not null"`
+	// Conditions mirrors the owning Argo Workflow's or custom CR's
+	// status.conditions summary, e.g. "Running", "Succeeded", "Failed".
+	Conditions string `gorm:"column:Conditions; not null"`
+	// CustomTaskRef is set when the run was created with a customTaskRef
+	// instead of a compiled Argo Workflow; nil for ordinary runs.
+	CustomTaskRef  *CustomTaskRef `gorm:"-"`
+	CreatedAtInSec int64          `gorm:"column:CreatedAtInSec; not null"`
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server/artifact"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// GetArtifactSignedURL returns a signed direct-download URL for an
+// artifact, letting StreamReadArtifact hand the client a URL instead of
+// proxying the bytes itself. inMemoryObjectStore cannot mint signed
+// URLs, so this always falls back to the empty string, which tells the
+// caller to proxy the download instead.
+func (r *ResourceManager) GetArtifactSignedURL(runID, nodeID, artifactName string) (string, error) {
+	return "", nil
+}
+
+// OpenArtifactRange returns a reader over the [rangeStart, rangeEnd)
+// byte range of an artifact, the same HTTP Range semantics
+// StreamReadArtifact exposes to its caller. A zero rangeEnd means "read
+// to the end of the artifact".
+func (r *ResourceManager) OpenArtifactRange(runID, nodeID, artifactName string, rangeStart, rangeEnd int64) (io.ReadCloser, error) {
+	data, err := r.objectStore.get(artifactObjectKey(runID, nodeID, artifactName))
+	if err != nil {
+		return nil, err
+	}
+	if rangeStart < 0 || rangeStart > int64(len(data)) {
+		return nil, util.NewInvalidInputError("range start %d is out of bounds for a %d byte artifact.", rangeStart, len(data))
+	}
+	if rangeEnd != 0 && rangeEnd < rangeStart {
+		return nil, util.NewInvalidInputError("range end %d is before range start %d.", rangeEnd, rangeStart)
+	}
+	end := int64(len(data))
+	if rangeEnd > 0 && rangeEnd < end {
+		end = rangeEnd
+	}
+	return ioutil.NopCloser(bytes.NewReader(data[rangeStart:end])), nil
+}
+
+// StartArtifactMultipartUpload opens a new multipart upload for the
+// artifact's eventual object key and returns that key alongside the
+// upload ID the caller must pass to PutArtifactUploadPart and
+// CompleteArtifactMultipartUpload.
+func (r *ResourceManager) StartArtifactMultipartUpload(runID, nodeID, artifactName string) (objectKey, uploadID string, err error) {
+	objectKey = artifactObjectKey(runID, nodeID, artifactName)
+	return objectKey, r.objectStore.startMultipartUpload(objectKey), nil
+}
+
+// PutArtifactUploadPart stores one block's bytes as a part of an
+// in-flight multipart upload, returning the part ID
+// CompleteArtifactMultipartUpload uses to reference it. index is accepted
+// to match the real object-store APIs (S3 parts are numbered), but the
+// in-memory store only needs the server-assigned part ID to reassemble.
+func (r *ResourceManager) PutArtifactUploadPart(uploadID, objectKey string, index int32, data []byte) (partID string, err error) {
+	return r.objectStore.putPart(uploadID, data)
+}
+
+// CompleteArtifactMultipartUpload assembles blocks, in the order given
+// (they are not re-sorted by Index), into the object store under
+// objectKey and discards the upload.
+func (r *ResourceManager) CompleteArtifactMultipartUpload(uploadID, objectKey string, blocks []artifact.Block) error {
+	partIDs := make([]string, len(blocks))
+	for i, block := range blocks {
+		partIDs[i] = block.PartID
+	}
+	return r.objectStore.completeMultipartUpload(uploadID, partIDs)
+}
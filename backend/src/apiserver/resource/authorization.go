@@ -0,0 +1,110 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/pkg/errors"
+)
+
+// ErrAuthorizationNotImplemented is returned by every subjectAccessAuthorizer
+// decision until it is wired to a real Kubernetes AuthorizationV1Client.
+// Callers must surface it as a hard RPC failure rather than treating it as
+// "not allowed": a fail-open stub that grants access, or a fail-closed stub
+// that silently reports every verb as denied, would both look like a real
+// authorization decision, and this authorizer isn't one yet.
+var ErrAuthorizationNotImplemented = errors.New(
+	"subjectAccessAuthorizer is not wired to a real Kubernetes SubjectAccessReview/SelfSubjectRulesReview client")
+
+// subjectAccessAuthorizer issues one SubjectAccessReview per (namespace,
+// verb) pair, the same per-call shape the Kubernetes API server expects.
+// The production implementation wraps the Kubernetes
+// AuthorizationV1Client passed in by client_manager.go; this in-process
+// build has no cluster to call out to, so every decision fails closed
+// with ErrAuthorizationNotImplemented instead of silently granting (or
+// denying) access.
+type subjectAccessAuthorizer struct{}
+
+func newSubjectAccessAuthorizer() *subjectAccessAuthorizer {
+	return &subjectAccessAuthorizer{}
+}
+
+func (a *subjectAccessAuthorizer) Authorize(ctx context.Context, namespace, verb string) error {
+	return ErrAuthorizationNotImplemented
+}
+
+// runVerbToResourceVerb maps a RunServer RPC name to the Kubernetes RBAC
+// verb a SubjectAccessReview should be issued for. "reportMetrics" has
+// no direct Kubernetes verb equivalent, since reporting a metric is an
+// apiserver-only operation guarded the same way a run update is.
+var runVerbToResourceVerb = map[string]string{
+	"get":           "get",
+	"archive":       "update",
+	"unarchive":     "update",
+	"delete":        "delete",
+	"terminate":     "update",
+	"retry":         "update",
+	"reportMetrics": "update",
+	"readArtifact":  "get",
+}
+
+// ListNamespacesWithRuns returns the distinct set of namespaces that own
+// at least one run, the candidate set ListAccessibleRuns batches
+// SubjectAccessReview calls against.
+func (r *ResourceManager) ListNamespacesWithRuns() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, run := range r.runs {
+		if !seen[run.Namespace] {
+			seen[run.Namespace] = true
+			namespaces = append(namespaces, run.Namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// IsAuthorizedForRunVerb issues a SubjectAccessReview for verb against
+// namespace through r.authorizer, returning nil when the caller is
+// allowed and an error otherwise. Decisions are not cached here.
+func (r *ResourceManager) IsAuthorizedForRunVerb(ctx context.Context, namespace, verb string) error {
+	resourceVerb, ok := runVerbToResourceVerb[verb]
+	if !ok {
+		return errors.Errorf("unknown run verb %q", verb)
+	}
+	return r.authorizer.Authorize(ctx, namespace, resourceVerb)
+}
+
+// ListRunsByNamespaces filters runs down to the provided namespace set,
+// pushing the authorization decision down to this query instead of
+// requiring one isAuthorized call per run in the result page.
+func (r *ResourceManager) ListRunsByNamespaces(namespaces []string, opts interface{}) ([]*model.Run, int, string, error) {
+	allowed := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		allowed[namespace] = true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var runs []*model.Run
+	for _, run := range r.runs {
+		if allowed[run.Namespace] {
+			runs = append(runs, run)
+		}
+	}
+	return runs, len(runs), "", nil
+}
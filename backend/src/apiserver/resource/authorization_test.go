@@ -0,0 +1,49 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListNamespacesWithRunsAndListRunsByNamespaces(t *testing.T) {
+	r := NewResourceManager()
+	_, err := r.CreateRun(&go_client.Run{Name: "run1", Namespace: "ns1"})
+	assert.Nil(t, err)
+	_, err = r.CreateRun(&go_client.Run{Name: "run2", Namespace: "ns2"})
+	assert.Nil(t, err)
+
+	namespaces, err := r.ListNamespacesWithRuns()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"ns1", "ns2"}, namespaces)
+
+	runs, total, _, err := r.ListRunsByNamespaces([]string{"ns1"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "ns1", runs[0].Namespace)
+}
+
+func TestIsAuthorizedForRunVerb(t *testing.T) {
+	r := NewResourceManager()
+	// The authorizer is an unwired stub: it must fail closed with
+	// ErrAuthorizationNotImplemented for a recognized verb rather than
+	// silently granting it.
+	assert.Equal(t, ErrAuthorizationNotImplemented, r.IsAuthorizedForRunVerb(context.Background(), "ns1", "get"))
+	assert.Error(t, r.IsAuthorizedForRunVerb(context.Background(), "ns1", "not-a-real-verb"))
+}
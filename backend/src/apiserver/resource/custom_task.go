@@ -0,0 +1,146 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+)
+
+// customTaskRegistry tracks the {namespace, group, kind} triples
+// operators have whitelisted for CustomTaskRef runs. It is backed by a
+// map for now; the production ResourceManager persists this table the
+// same way it persists everything else, through the shared DB handle
+// passed in by client_manager.go.
+type customTaskRegistry struct {
+	mu            sync.RWMutex
+	registrations map[model.CustomTaskRegistration]bool
+}
+
+func newCustomTaskRegistry() *customTaskRegistry {
+	return &customTaskRegistry{registrations: make(map[model.CustomTaskRegistration]bool)}
+}
+
+func (c *customTaskRegistry) register(registration *model.CustomTaskRegistration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.registrations[*registration] = true
+}
+
+func (c *customTaskRegistry) unregister(namespace, group, kind string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.registrations, model.CustomTaskRegistration{Namespace: namespace, Group: group, Kind: kind})
+}
+
+func (c *customTaskRegistry) isRegistered(namespace, group, kind string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.registrations[model.CustomTaskRegistration{Namespace: namespace, Group: group, Kind: kind}]
+}
+
+func (c *customTaskRegistry) list(namespace string) []*model.CustomTaskRegistration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var registrations []*model.CustomTaskRegistration
+	for registration := range c.registrations {
+		if namespace == "" || registration.Namespace == namespace {
+			registration := registration
+			registrations = append(registrations, &registration)
+		}
+	}
+	return registrations
+}
+
+// RegisterCustomTaskType whitelists a {group, kind} pair for namespace.
+func (r *ResourceManager) RegisterCustomTaskType(registration *model.CustomTaskRegistration) error {
+	r.customTasks.register(registration)
+	return nil
+}
+
+// UnregisterCustomTaskType removes a previously whitelisted {group, kind}
+// pair from namespace.
+func (r *ResourceManager) UnregisterCustomTaskType(namespace, group, kind string) error {
+	r.customTasks.unregister(namespace, group, kind)
+	return nil
+}
+
+// ListCustomTaskTypes returns the registrations for namespace, or every
+// registration across all namespaces when namespace is empty.
+func (r *ResourceManager) ListCustomTaskTypes(namespace string) ([]*model.CustomTaskRegistration, error) {
+	return r.customTasks.list(namespace), nil
+}
+
+// IsCustomTaskTypeRegistered reports whether {group, kind} has been
+// whitelisted for namespace.
+func (r *ResourceManager) IsCustomTaskTypeRegistered(namespace, group, kind string) (bool, error) {
+	return r.customTasks.isRegistered(namespace, group, kind), nil
+}
+
+// SyncCustomTaskRunStatus refreshes run's condition to reflect what this
+// package can actually observe about its owning custom task CR. The
+// production ResourceManager instead watches the CR directly and copies
+// its status/conditions onto the run the same way the Argo Workflow
+// informer does for ordinary runs; this in-process build has no CR to
+// watch, so the only live signal it has is whether the {group, kind} is
+// still registered, and it degrades the run to "Unknown" once that
+// registration is withdrawn.
+func (r *ResourceManager) SyncCustomTaskRunStatus(run *model.Run) error {
+	if run.CustomTaskRef == nil {
+		return nil
+	}
+	registered, err := r.IsCustomTaskTypeRegistered(run.Namespace, model.CustomTaskGroup(run.CustomTaskRef.APIVersion), run.CustomTaskRef.Kind)
+	if err != nil {
+		return err
+	}
+	if !registered && run.Conditions != "Unknown" {
+		if err := r.setCondition(run.UUID, "Unknown"); err != nil {
+			return err
+		}
+		run.Conditions = "Unknown"
+	}
+	return nil
+}
+
+// CreateCustomTaskRun persists a run delegated to a custom task
+// controller. Instead of compiling and submitting an Argo Workflow, it
+// creates a lightweight custom resource in the run's namespace (here
+// represented by simply recording the CustomTaskRef on the run; the
+// production implementation additionally creates the unstructured CR
+// through the dynamic Kubernetes client supplied by client_manager.go
+// and starts a watch that calls setCondition as the CR's status
+// changes, the same way the Argo Workflow informer does for ordinary
+// runs).
+func (r *ResourceManager) CreateCustomTaskRun(apiRun *go_client.Run) (*model.Run, error) {
+	run := &model.Run{
+		UUID:           uuid.New().String(),
+		Name:           apiRun.GetName(),
+		Namespace:      apiRun.GetNamespace(),
+		ServiceAccount: apiRun.GetServiceAccount(),
+		Conditions:     "Running",
+		CustomTaskRef: &model.CustomTaskRef{
+			APIVersion: apiRun.GetCustomTaskRef().GetApiVersion(),
+			Kind:       apiRun.GetCustomTaskRef().GetKind(),
+			Name:       apiRun.GetCustomTaskRef().GetName(),
+		},
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[run.UUID] = run
+	return run, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomTaskTypeRegistration(t *testing.T) {
+	r := NewResourceManager()
+
+	registered, err := r.IsCustomTaskTypeRegistered("ns1", "custom.tekton.dev", "PythonTask")
+	assert.Nil(t, err)
+	assert.False(t, registered)
+
+	err = r.RegisterCustomTaskType(&model.CustomTaskRegistration{Namespace: "ns1", Group: "custom.tekton.dev", Kind: "PythonTask"})
+	assert.Nil(t, err)
+
+	registered, err = r.IsCustomTaskTypeRegistered("ns1", "custom.tekton.dev", "PythonTask")
+	assert.Nil(t, err)
+	assert.True(t, registered)
+
+	// A different namespace was never granted this {group, kind}.
+	registered, err = r.IsCustomTaskTypeRegistered("ns2", "custom.tekton.dev", "PythonTask")
+	assert.Nil(t, err)
+	assert.False(t, registered)
+
+	err = r.UnregisterCustomTaskType("ns1", "custom.tekton.dev", "PythonTask")
+	assert.Nil(t, err)
+	registered, err = r.IsCustomTaskTypeRegistered("ns1", "custom.tekton.dev", "PythonTask")
+	assert.Nil(t, err)
+	assert.False(t, registered)
+}
+
+func TestCreateCustomTaskRun(t *testing.T) {
+	r := NewResourceManager()
+	run, err := r.CreateCustomTaskRun(&go_client.Run{
+		Name:      "custom-run",
+		Namespace: "ns1",
+		CustomTaskRef: &go_client.CustomTaskRef{
+			ApiVersion: "custom.tekton.dev/v1alpha1",
+			Kind:       "PythonTask",
+			Name:       "my-task",
+		},
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, run.CustomTaskRef)
+	assert.Equal(t, "custom.tekton.dev/v1alpha1", run.CustomTaskRef.APIVersion)
+
+	err = r.TerminateRun(run.UUID)
+	assert.Nil(t, err)
+	terminated, err := r.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Terminating", terminated.Conditions)
+}
+
+func TestSyncCustomTaskRunStatus(t *testing.T) {
+	r := NewResourceManager()
+	err := r.RegisterCustomTaskType(&model.CustomTaskRegistration{Namespace: "ns1", Group: "custom.tekton.dev", Kind: "PythonTask"})
+	assert.Nil(t, err)
+
+	run, err := r.CreateCustomTaskRun(&go_client.Run{
+		Name:      "custom-run",
+		Namespace: "ns1",
+		CustomTaskRef: &go_client.CustomTaskRef{
+			ApiVersion: "custom.tekton.dev/v1alpha1",
+			Kind:       "PythonTask",
+			Name:       "my-task",
+		},
+	})
+	assert.Nil(t, err)
+
+	// While the {group, kind} stays registered, syncing leaves the run's
+	// condition untouched.
+	assert.Nil(t, r.SyncCustomTaskRunStatus(run))
+	assert.Equal(t, "Running", run.Conditions)
+
+	// Once the owning controller's type is unregistered, this package can
+	// no longer observe the CR's real status; syncing should reflect that.
+	err = r.UnregisterCustomTaskType("ns1", "custom.tekton.dev", "PythonTask")
+	assert.Nil(t, err)
+	assert.Nil(t, r.SyncCustomTaskRunStatus(run))
+	assert.Equal(t, "Unknown", run.Conditions)
+
+	persisted, err := r.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Unknown", persisted.Conditions)
+}
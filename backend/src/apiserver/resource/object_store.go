@@ -0,0 +1,113 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// inMemoryObjectStore is a minimal stand-in for the S3/GCS/Minio-backed
+// object store client.ResourceManager wraps in production. It supports
+// whole-object reads/writes, plus the handful of multipart operations
+// StreamWriteArtifact needs; it does not support signed URLs, so
+// GetArtifactSignedURL always falls back to server-proxied streaming.
+type inMemoryObjectStore struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+
+	uploads map[string]*multipartUpload
+}
+
+// multipartUpload tracks the parts PutArtifactUploadPart has received for
+// one in-flight upload, along with the object key the blocks will be
+// assembled under once CompleteArtifactMultipartUpload runs.
+type multipartUpload struct {
+	objectKey string
+	parts     map[string][]byte
+}
+
+func newInMemoryObjectStore() *inMemoryObjectStore {
+	return &inMemoryObjectStore{
+		objects: make(map[string][]byte),
+		uploads: make(map[string]*multipartUpload),
+	}
+}
+
+func (o *inMemoryObjectStore) get(key string) ([]byte, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	data, ok := o.objects[key]
+	if !ok {
+		return nil, util.NewResourceNotFoundError("Artifact", key)
+	}
+	return data, nil
+}
+
+func (o *inMemoryObjectStore) put(key string, data []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.objects[key] = data
+}
+
+// startMultipartUpload opens a new multipart upload for objectKey and
+// returns the upload ID PutArtifactUploadPart/CompleteArtifactMultipartUpload
+// reference it by, the same handle an S3 CreateMultipartUpload call
+// would return.
+func (o *inMemoryObjectStore) startMultipartUpload(objectKey string) string {
+	uploadID := uuid.New().String()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.uploads[uploadID] = &multipartUpload{objectKey: objectKey, parts: make(map[string][]byte)}
+	return uploadID
+}
+
+// putPart stores one part's bytes under a server-assigned part ID.
+func (o *inMemoryObjectStore) putPart(uploadID string, data []byte) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	upload, ok := o.uploads[uploadID]
+	if !ok {
+		return "", util.NewResourceNotFoundError("ArtifactUpload", uploadID)
+	}
+	partID := uuid.New().String()
+	upload.parts[partID] = data
+	return partID, nil
+}
+
+// completeMultipartUpload assembles partIDs, in order, into the object
+// store under the upload's object key, then discards the upload.
+func (o *inMemoryObjectStore) completeMultipartUpload(uploadID string, partIDs []string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	upload, ok := o.uploads[uploadID]
+	if !ok {
+		return util.NewResourceNotFoundError("ArtifactUpload", uploadID)
+	}
+	var assembled bytes.Buffer
+	for _, partID := range partIDs {
+		part, ok := upload.parts[partID]
+		if !ok {
+			return util.NewInvalidInputError("Upload %q has no part %q.", uploadID, partID)
+		}
+		assembled.Write(part)
+	}
+	o.objects[upload.objectKey] = assembled.Bytes()
+	delete(o.uploads, uploadID)
+	return nil
+}
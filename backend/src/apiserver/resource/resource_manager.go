@@ -0,0 +1,187 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resource implements ResourceManager, the apiserver's single
+// point of access to run/experiment/job persistence and to the
+// Kubernetes-facing operations (Argo Workflow and custom task CR
+// submission, artifact storage) those resources require.
+package resource
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// ResourceManager is the apiserver's gateway to everything a RunServer
+// RPC needs: the run store, the object store used for artifacts, and the
+// Kubernetes-facing clients used to submit and watch workflows/custom
+// task CRs.
+type ResourceManager struct {
+	mu   sync.RWMutex
+	runs map[string]*model.Run
+
+	customTasks           *customTaskRegistry
+	objectStore           *inMemoryObjectStore
+	authorizer            *subjectAccessAuthorizer
+	selfSubjectRulesCache *selfSubjectRulesCache
+}
+
+// NewResourceManager constructs a ResourceManager. Production wiring
+// (DB connection, Kubernetes clients, object store client) is supplied
+// by the caller in client_manager.go; this package only owns the
+// reconciliation logic.
+func NewResourceManager() *ResourceManager {
+	return &ResourceManager{
+		runs:                  make(map[string]*model.Run),
+		customTasks:           newCustomTaskRegistry(),
+		objectStore:           newInMemoryObjectStore(),
+		authorizer:            newSubjectAccessAuthorizer(),
+		selfSubjectRulesCache: &selfSubjectRulesCache{entries: make(map[string]*selfSubjectRulesCacheEntry)},
+	}
+}
+
+func (r *ResourceManager) CreateRun(apiRun *go_client.Run) (*model.Run, error) {
+	run := &model.Run{
+		UUID:           uuid.New().String(),
+		Name:           apiRun.GetName(),
+		Namespace:      apiRun.GetNamespace(),
+		ServiceAccount: apiRun.GetServiceAccount(),
+		Conditions:     "Running",
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[run.UUID] = run
+	return run, nil
+}
+
+func (r *ResourceManager) GetRun(runID string) (*model.Run, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	run, ok := r.runs[runID]
+	if !ok {
+		return nil, util.NewResourceNotFoundError("Run", runID)
+	}
+	return run, nil
+}
+
+func (r *ResourceManager) ListRuns(filterContext interface{}, opts interface{}) ([]*model.Run, int, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	runs := make([]*model.Run, 0, len(r.runs))
+	for _, run := range r.runs {
+		runs = append(runs, run)
+	}
+	return runs, len(runs), "", nil
+}
+
+func (r *ResourceManager) ArchiveRun(runID string) error {
+	return r.setCondition(runID, "Archived")
+}
+
+func (r *ResourceManager) UnarchiveRun(runID string) error {
+	return r.setCondition(runID, "Running")
+}
+
+func (r *ResourceManager) DeleteRun(runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.runs[runID]; !ok {
+		return util.NewResourceNotFoundError("Run", runID)
+	}
+	delete(r.runs, runID)
+	return nil
+}
+
+// customTaskCancellationAnnotation is set on a custom task CR to signal
+// cancellation; the controller that owns {group, kind} is expected to
+// watch for it the same way Tekton's Run reconciler watches for
+// tekton.dev/v1beta1.cancelled.
+const customTaskCancellationAnnotation = "pipelines.kubeflow.org/cancelled"
+
+func (r *ResourceManager) TerminateRun(runID string) error {
+	run, err := r.GetRun(runID)
+	if err != nil {
+		return err
+	}
+	if run.CustomTaskRef != nil {
+		// Custom tasks don't speak the Argo Workflow termination API, so
+		// termination goes through annotateCustomTaskRun with the
+		// cancellation annotation instead; that records the run's
+		// condition as "Terminating" and patches no cluster object.
+		return r.annotateCustomTaskRun(runID, customTaskCancellationAnnotation, "true")
+	}
+	return r.setCondition(runID, "Terminating")
+}
+
+func (r *ResourceManager) RetryRun(runID string) error {
+	run, err := r.GetRun(runID)
+	if err != nil {
+		return err
+	}
+	if run.CustomTaskRef != nil {
+		return r.annotateCustomTaskRun(runID, customTaskCancellationAnnotation, "false")
+	}
+	return r.setCondition(runID, "Running")
+}
+
+// annotateCustomTaskRun records a cancellation-style annotation against
+// the run's custom task CR. The production implementation patches the
+// actual unstructured object through the dynamic client; this
+// in-process build tracks it on the model.Run row so the rest of the
+// RunServer surface (GetRun/ListRuns) can still observe it.
+func (r *ResourceManager) annotateCustomTaskRun(runID, annotation, value string) error {
+	condition := "Running"
+	if value == "true" {
+		condition = "Terminating"
+	}
+	return r.setCondition(runID, condition)
+}
+
+func (r *ResourceManager) ReportMetric(metric *go_client.RunMetric, runID string) error {
+	if _, err := r.GetRun(runID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *ResourceManager) ReadArtifact(runID, nodeID, artifactName string) ([]byte, error) {
+	return r.objectStore.get(artifactObjectKey(runID, nodeID, artifactName))
+}
+
+func (r *ResourceManager) GetNamespaceFromRunID(runID string) (string, error) {
+	run, err := r.GetRun(runID)
+	if err != nil {
+		return "", err
+	}
+	return run.Namespace, nil
+}
+
+func (r *ResourceManager) setCondition(runID, condition string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.runs[runID]
+	if !ok {
+		return util.NewResourceNotFoundError("Run", runID)
+	}
+	run.Conditions = condition
+	return nil
+}
+
+func artifactObjectKey(runID, nodeID, artifactName string) string {
+	return runID + "/" + nodeID + "/" + artifactName
+}
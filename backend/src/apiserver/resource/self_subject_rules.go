@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"google.golang.org/grpc/metadata"
+)
+
+// identityContextKey is the context key a gRPC authentication
+// interceptor may stash an already-resolved caller identity under,
+// letting callers (and tests) short-circuit the metadata lookup below.
+// It is unexported so only this package's own wiring can set it.
+type identityContextKey struct{}
+
+// identityFromContext extracts the caller's identity for cache keying.
+// It prefers an identity already resolved onto ctx via
+// identityContextKey, then falls back to reading the configured
+// Kubeflow user-id header (the same header/prefix multi-user mode's
+// authenticators key off) straight from the incoming gRPC metadata,
+// since this package has no authentication interceptor of its own to
+// populate identityContextKey in production. It defaults to the empty
+// string when neither source has one (single-user mode, or a test
+// context with no metadata attached).
+func identityFromContext(ctx context.Context) string {
+	if identity, ok := ctx.Value(identityContextKey{}).(string); ok {
+		return identity
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(strings.ToLower(common.GetKubeflowUserIDHeader()))
+	if len(values) != 1 {
+		return ""
+	}
+	return strings.TrimPrefix(values[0], common.GetKubeflowUserIDPrefix())
+}
+
+// selfSubjectRules caches the outcome of a single SelfSubjectRulesReview
+// for a namespace: unlike SubjectAccessReview, a SelfSubjectRulesReview
+// enumerates every verb the caller holds in one round trip, so
+// GetRunPermissions only needs one cache entry per namespace rather than
+// one per verb.
+type selfSubjectRules struct {
+	verbs map[string]bool
+}
+
+// Can reports whether the cached rules grant verb, along with a
+// human-readable reason suitable for GetRunPermissionsResponse_Permission.
+func (s *selfSubjectRules) Can(verb string) (bool, string) {
+	if s.verbs[verb] {
+		return true, ""
+	}
+	return false, "not granted by any binding visible to SelfSubjectRulesReview"
+}
+
+// selfSubjectRulesCache holds recently-fetched SelfSubjectRulesReview
+// results keyed by "identity/namespace", each valid for
+// selfSubjectRulesTTL.
+type selfSubjectRulesCache struct {
+	mu      sync.Mutex
+	entries map[string]*selfSubjectRulesCacheEntry
+}
+
+type selfSubjectRulesCacheEntry struct {
+	rules     *selfSubjectRules
+	expiresAt time.Time
+}
+
+// selfSubjectRulesTTL bounds how stale a cached permission set can be:
+// long enough to avoid re-issuing a SelfSubjectRulesReview on every
+// GetRunPermissions call in a tight polling loop, short enough that a
+// revoked RoleBinding takes effect for the caller within the same order
+// of magnitude as kubectl auth can-i would observe it.
+const selfSubjectRulesTTL = 30 * time.Second
+
+// selfSubjectRules issues a SelfSubjectRulesReview scoped to namespace.
+// The production implementation calls the Kubernetes
+// AuthorizationV1Client's SelfSubjectRulesReviews().Create the same way
+// `kubectl auth can-i --list` does; this in-process build has no cluster
+// to call out to, so it fails closed with ErrAuthorizationNotImplemented,
+// the same stand-in subjectAccessAuthorizer.Authorize uses.
+func (a *subjectAccessAuthorizer) selfSubjectRules(ctx context.Context, namespace string) (*selfSubjectRules, error) {
+	return nil, ErrAuthorizationNotImplemented
+}
+
+// GetCachedSelfSubjectRules returns the caller's SelfSubjectRulesReview
+// result for namespace, issuing a fresh review (and caching it for
+// selfSubjectRulesTTL) on a cache miss or expiry. The cache is keyed on
+// both the caller's identity (from ctx) and namespace, so one caller's
+// cached rules are never served back to a different caller asking about
+// the same namespace.
+func (r *ResourceManager) GetCachedSelfSubjectRules(ctx context.Context, namespace string) (*selfSubjectRules, error) {
+	key := identityFromContext(ctx) + "/" + namespace
+	r.selfSubjectRulesCache.mu.Lock()
+	defer r.selfSubjectRulesCache.mu.Unlock()
+	if entry, ok := r.selfSubjectRulesCache.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		return entry.rules, nil
+	}
+	rules, err := r.authorizer.selfSubjectRules(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	r.selfSubjectRulesCache.entries[key] = &selfSubjectRulesCacheEntry{
+		rules:     rules,
+		expiresAt: time.Now().Add(selfSubjectRulesTTL),
+	}
+	return rules, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGetCachedSelfSubjectRules(t *testing.T) {
+	r := NewResourceManager()
+
+	// The authorizer is an unwired stub: it must fail closed with
+	// ErrAuthorizationNotImplemented rather than silently granting every
+	// verb, and a failed review must not be cached.
+	rules, err := r.GetCachedSelfSubjectRules(context.Background(), "ns1")
+	assert.Nil(t, rules)
+	assert.Equal(t, ErrAuthorizationNotImplemented, err)
+	assert.Empty(t, r.selfSubjectRulesCache.entries)
+}
+
+// TestIdentityFromContextKeysCacheByIdentityAndNamespace guards against
+// one caller's cached rules being served back to a different caller
+// asking about the same namespace: the cache key must incorporate
+// identity as well as namespace, and identity must resolve to distinct
+// values for distinct callers.
+func TestIdentityFromContextKeysCacheByIdentityAndNamespace(t *testing.T) {
+	aliceCtx := context.WithValue(context.Background(), identityContextKey{}, "alice")
+	bobCtx := context.WithValue(context.Background(), identityContextKey{}, "bob")
+
+	assert.Equal(t, "alice", identityFromContext(aliceCtx))
+	assert.Equal(t, "bob", identityFromContext(bobCtx))
+	assert.NotEqual(t, identityFromContext(aliceCtx), identityFromContext(bobCtx))
+}
+
+// TestIdentityFromContextFallsBackToIncomingMetadata exercises the real
+// production path: with no identityContextKey set (no interceptor in
+// this package), the caller's identity must still be resolved from the
+// incoming gRPC metadata the same way multi-user mode's authenticators
+// read it, rather than collapsing to the empty string for every caller.
+func TestIdentityFromContextFallsBackToIncomingMetadata(t *testing.T) {
+	header := strings.ToLower(common.GetKubeflowUserIDHeader())
+	md := metadata.New(map[string]string{header: common.GetKubeflowUserIDPrefix() + "alice@example.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	assert.Equal(t, "alice@example.com", identityFromContext(ctx))
+	assert.Empty(t, identityFromContext(context.Background()))
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package artifact implements the chunked, resumable artifact
+// upload/download protocol: a client opens a session, PUTs fixed-size
+// blocks identified by index and SHA256, then commits the ordered block
+// list so the server can assemble them into the object store via a
+// multipart upload.
+package artifact
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// sessionTTL bounds how long after its creation an upload session is kept
+// before SessionStore.Create reclaims it, whether or not blocks are still
+// arriving. Reclaiming removes only the session record; the multipart
+// upload it opened in the backing object store is not aborted.
+const sessionTTL = 24 * time.Hour
+
+// Block is one fixed-size chunk of an artifact upload, identified by its
+// position in the stream and checksummed so the server can detect
+// corruption or out-of-order delivery before committing.
+type Block struct {
+	Index  int32
+	SHA256 string
+	Size   int64
+	// PartID identifies this block's corresponding multipart part in the
+	// backing object store (S3/GCS/Minio), so Commit can reference it
+	// without re-uploading.
+	PartID string
+}
+
+// Session tracks one in-flight chunked upload. RunID/NodeID/ArtifactName
+// identify where the assembled artifact will be written once committed.
+type Session struct {
+	Token        string
+	RunID        string
+	NodeID       string
+	ArtifactName string
+	ObjectKey    string
+	UploadID     string
+	CreatedAt    time.Time
+
+	mu     sync.Mutex
+	blocks map[int32]Block
+}
+
+// AddBlock records a successfully stored block. Blocks may arrive out of
+// order or be retried by the client after a network blip; a retry simply
+// overwrites the previous record for the same index.
+func (s *Session) AddBlock(block Block) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.blocks == nil {
+		s.blocks = make(map[int32]Block)
+	}
+	s.blocks[block.Index] = block
+}
+
+// OrderedBlocks returns the recorded blocks sorted by index, erroring if
+// any index in [0, highest] is missing so a partially delivered upload
+// cannot be committed.
+func (s *Session) OrderedBlocks() ([]Block, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ordered := make([]Block, len(s.blocks))
+	for index, block := range s.blocks {
+		if index < 0 || int(index) >= len(ordered) {
+			return nil, errors.Errorf("block index %d has no predecessor blocks; upload has gaps", index)
+		}
+		ordered[index] = block
+	}
+	for i, block := range ordered {
+		if block.SHA256 == "" {
+			return nil, errors.Errorf("missing block at index %d", i)
+		}
+	}
+	return ordered, nil
+}
+
+// SessionStore tracks in-flight upload sessions in memory, keyed by
+// token, with best-effort reclamation of sessions the client abandoned.
+type SessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewSessionStore creates an empty SessionStore.
+func NewSessionStore() *SessionStore {
+	return &SessionStore{sessions: make(map[string]*Session)}
+}
+
+// Create starts a new upload session and returns its token.
+func (s *SessionStore) Create(runID, nodeID, artifactName, objectKey, uploadID string) *Session {
+	session := &Session{
+		Token:        uuid.New().String(),
+		RunID:        runID,
+		NodeID:       nodeID,
+		ArtifactName: artifactName,
+		ObjectKey:    objectKey,
+		UploadID:     uploadID,
+		CreatedAt:    time.Now(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reclaimExpiredLocked()
+	s.sessions[session.Token] = session
+	return session
+}
+
+// Get looks up a session by token.
+func (s *SessionStore) Get(token string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[token]
+	if !ok {
+		return nil, errors.Errorf("unknown or expired upload session %q", token)
+	}
+	return session, nil
+}
+
+// Complete removes a session once its upload has been committed or
+// aborted.
+func (s *SessionStore) Complete(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, token)
+}
+
+func (s *SessionStore) reclaimExpiredLocked() {
+	cutoff := time.Now().Add(-sessionTTL)
+	for token, session := range s.sessions {
+		if session.CreatedAt.Before(cutoff) {
+			delete(s.sessions, token)
+		}
+	}
+}
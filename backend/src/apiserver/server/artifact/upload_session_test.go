@@ -0,0 +1,43 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionOrderedBlocks(t *testing.T) {
+	store := NewSessionStore()
+	session := store.Create("run1", "node1", "model.txt", "object-key", "upload-id")
+
+	session.AddBlock(Block{Index: 0, SHA256: "a", Size: 1})
+	session.AddBlock(Block{Index: 1, SHA256: "b", Size: 1})
+	ordered, err := session.OrderedBlocks()
+	assert.Nil(t, err)
+	assert.Len(t, ordered, 2)
+}
+
+func TestSessionOrderedBlocksRejectsNegativeIndex(t *testing.T) {
+	store := NewSessionStore()
+	session := store.Create("run1", "node1", "model.txt", "object-key", "upload-id")
+
+	// A client-supplied negative index must be rejected, not panic the
+	// RPC goroutine with a negative array index.
+	session.AddBlock(Block{Index: -1, SHA256: "a", Size: 1})
+	_, err := session.OrderedBlocks()
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server/artifact"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// artifactDownloadChunkSize bounds how much of an artifact is read into
+// memory at a time when the object store backend doesn't support signed
+// direct-download URLs and the server must proxy the bytes itself.
+const artifactDownloadChunkSize = 4 * 1024 * 1024 // 4MiB
+
+// StreamReadArtifact streams an artifact to the client in fixed-size
+// chunks, honoring the HTTP Range-style [RangeStart, RangeEnd) byte range
+// on the request. If
+// the backing object store can mint a signed direct-download URL, the
+// server returns that instead of proxying the bytes itself.
+func (s *RunServer) StreamReadArtifact(request *api.ReadArtifactRequest, stream api.RunService_StreamReadArtifactServer) error {
+	if err := s.canAccessRun(stream.Context(), request.GetRunId()); err != nil {
+		return util.Wrap(err, "Failed to authorize the request.")
+	}
+
+	if url, err := s.resourceManager.GetArtifactSignedURL(request.GetRunId(), request.GetNodeId(), request.GetArtifactName()); err == nil && url != "" {
+		return stream.Send(&api.ReadArtifactChunk{SignedUrl: url})
+	}
+
+	reader, err := s.resourceManager.OpenArtifactRange(
+		request.GetRunId(), request.GetNodeId(), request.GetArtifactName(), request.GetRangeStart(), request.GetRangeEnd())
+	if err != nil {
+		return util.Wrapf(err, "failed to open artifact '%+v'.", request)
+	}
+	defer reader.Close()
+
+	buffer := make([]byte, artifactDownloadChunkSize)
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			if sendErr := stream.Send(&api.ReadArtifactChunk{Data: append([]byte(nil), buffer[:n]...)}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return util.Wrapf(err, "failed to read artifact '%+v'.", request)
+		}
+	}
+}
+
+// StreamWriteArtifact implements the chunked, resumable upload protocol:
+// the first message on the stream opens a session (or resumes one from a
+// previously issued session token), subsequent messages each carry one
+// checksummed block, and a final commit message triggers assembly of the
+// blocks into the underlying object store via a multipart upload.
+func (s *RunServer) StreamWriteArtifact(stream api.RunService_StreamWriteArtifactServer) error {
+	var session *artifact.Session
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return util.NewInvalidInputError("Stream closed before artifact upload was committed.")
+		}
+		if err != nil {
+			return err
+		}
+
+		if session == nil {
+			session, err = s.openOrResumeUploadSession(stream.Context(), req)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch payload := req.GetPayload().(type) {
+		case *api.WriteArtifactRequest_Block:
+			if err := validateBlockChecksum(payload.Block); err != nil {
+				return util.Wrap(err, "Block checksum validation failed.")
+			}
+			partID, err := s.resourceManager.PutArtifactUploadPart(
+				session.UploadID, session.ObjectKey, payload.Block.Index, payload.Block.Data)
+			if err != nil {
+				return util.Wrap(err, "Failed to store artifact block.")
+			}
+			session.AddBlock(artifact.Block{
+				Index:  payload.Block.Index,
+				SHA256: payload.Block.Sha256,
+				Size:   int64(len(payload.Block.Data)),
+				PartID: partID,
+			})
+		case *api.WriteArtifactRequest_Commit:
+			return s.commitArtifactUpload(stream, session)
+		}
+	}
+}
+
+func (s *RunServer) openOrResumeUploadSession(ctx context.Context, req *api.WriteArtifactRequest) (*artifact.Session, error) {
+	if token := req.GetSessionToken(); token != "" {
+		return s.uploadSessions.Get(token)
+	}
+	if err := s.canAccessRun(ctx, req.GetRunId()); err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request.")
+	}
+	objectKey, uploadID, err := s.resourceManager.StartArtifactMultipartUpload(req.GetRunId(), req.GetNodeId(), req.GetArtifactName())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to start artifact upload session.")
+	}
+	return s.uploadSessions.Create(req.GetRunId(), req.GetNodeId(), req.GetArtifactName(), objectKey, uploadID), nil
+}
+
+func (s *RunServer) commitArtifactUpload(stream api.RunService_StreamWriteArtifactServer, session *artifact.Session) error {
+	blocks, err := session.OrderedBlocks()
+	if err != nil {
+		return util.Wrap(err, "Cannot commit an incomplete artifact upload.")
+	}
+	if err := s.resourceManager.CompleteArtifactMultipartUpload(session.UploadID, session.ObjectKey, blocks); err != nil {
+		return util.Wrap(err, "Failed to commit artifact upload.")
+	}
+	s.uploadSessions.Complete(session.Token)
+	return stream.SendAndClose(&api.WriteArtifactResponse{SessionToken: session.Token})
+}
+
+func validateBlockChecksum(block *api.ArtifactBlock) error {
+	sum := sha256.Sum256(block.GetData())
+	if hex.EncodeToString(sum[:]) != block.GetSha256() {
+		return util.NewInvalidInputError("Block %d failed SHA256 validation.", block.GetIndex())
+	}
+	return nil
+}
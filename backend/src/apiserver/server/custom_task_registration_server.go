@@ -0,0 +1,88 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// CustomTaskRegistrationServer lets operators whitelist which
+// {apiVersion group, kind} pairs a namespace's runs are permitted to
+// delegate to via CustomTaskRef, so that an unprivileged user cannot
+// point a run at an arbitrary, possibly unsafe, controller.
+type CustomTaskRegistrationServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+func (s *CustomTaskRegistrationServer) RegisterCustomTaskType(ctx context.Context, request *api.RegisterCustomTaskTypeRequest) (*empty.Empty, error) {
+	if err := isAuthorized(s.resourceManager, ctx, request.Namespace); err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request.")
+	}
+	if request.Group == "" || request.Kind == "" {
+		return nil, util.NewInvalidInputError("Both group and kind must be specified to register a custom task type.")
+	}
+	err := s.resourceManager.RegisterCustomTaskType(&model.CustomTaskRegistration{
+		Namespace: request.Namespace,
+		Group:     request.Group,
+		Kind:      request.Kind,
+	})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to register custom task type.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *CustomTaskRegistrationServer) UnregisterCustomTaskType(ctx context.Context, request *api.UnregisterCustomTaskTypeRequest) (*empty.Empty, error) {
+	if err := isAuthorized(s.resourceManager, ctx, request.Namespace); err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request.")
+	}
+	err := s.resourceManager.UnregisterCustomTaskType(request.Namespace, request.Group, request.Kind)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to unregister custom task type.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *CustomTaskRegistrationServer) ListCustomTaskTypes(ctx context.Context, request *api.ListCustomTaskTypesRequest) (*api.ListCustomTaskTypesResponse, error) {
+	if common.IsMultiUserMode() {
+		if err := isAuthorized(s.resourceManager, ctx, request.Namespace); err != nil {
+			return nil, util.Wrap(err, "Failed to authorize the request.")
+		}
+	}
+	registrations, err := s.resourceManager.ListCustomTaskTypes(request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to list registered custom task types.")
+	}
+	response := &api.ListCustomTaskTypesResponse{}
+	for _, registration := range registrations {
+		response.CustomTaskTypes = append(response.CustomTaskTypes, &api.CustomTaskType{
+			Namespace: registration.Namespace,
+			Group:     registration.Group,
+			Kind:      registration.Kind,
+		})
+	}
+	return response, nil
+}
+
+func NewCustomTaskRegistrationServer(resourceManager *resource.ResourceManager) *CustomTaskRegistrationServer {
+	return &CustomTaskRegistrationServer{resourceManager: resourceManager}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+)
+
+// Event types emitted for run lifecycle transitions. These follow the
+// reverse-DNS convention recommended by the CloudEvents spec.
+const (
+	TypeRunCreated    = "org.kubeflow.pipelines.run.created"
+	TypeRunArchived   = "org.kubeflow.pipelines.run.archived"
+	TypeRunUnarchived = "org.kubeflow.pipelines.run.unarchived"
+	TypeRunDeleted    = "org.kubeflow.pipelines.run.deleted"
+	TypeRunTerminated = "org.kubeflow.pipelines.run.terminated"
+	TypeRunRetried    = "org.kubeflow.pipelines.run.retried"
+	TypeRunMetrics    = "org.kubeflow.pipelines.run.metricsReported"
+
+	source = "kubeflow-pipelines/apiserver"
+)
+
+// NewRunEvent builds a CloudEvent (spec v1.0) describing a run lifecycle
+// transition. subject is the run ID and data is the API payload (run or
+// metric) serialized as JSON in the event body.
+func NewRunEvent(eventType, runID, namespace string, data interface{}) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetSource(source)
+	event.SetType(eventType)
+	event.SetSubject(runID)
+	event.SetExtension("namespace", namespace)
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return cloudevents.Event{}, err
+	}
+	return event, nil
+}
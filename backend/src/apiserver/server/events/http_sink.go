@@ -0,0 +1,51 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/pkg/errors"
+)
+
+// HTTPSink delivers CloudEvents as HTTP POSTs in the SDK's default
+// binary content mode to a single configured endpoint, e.g. a dashboard
+// or audit webhook.
+type HTTPSink struct {
+	name   string
+	client cloudevents.Client
+	target string
+}
+
+// NewHTTPSink builds a Sink that POSTs CloudEvents to targetURL.
+func NewHTTPSink(name, targetURL string) (*HTTPSink, error) {
+	client, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CloudEvents HTTP client")
+	}
+	return &HTTPSink{name: name, client: client, target: targetURL}, nil
+}
+
+func (s *HTTPSink) Name() string { return s.name }
+
+func (s *HTTPSink) Send(ctx context.Context, event cloudevents.Event) error {
+	ctx = cloudevents.ContextWithTarget(ctx, s.target)
+	result := s.client.Send(ctx, event)
+	if cloudevents.IsUndelivered(result) || cloudevents.IsNACK(result) {
+		return errors.Wrapf(result, "failed to deliver event to %s", s.target)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/pkg/errors"
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// KafkaSink publishes CloudEvents (structured-mode JSON) to a Kafka topic,
+// keyed by run ID so that all events for a run land on the same
+// partition and preserve ordering for consumers.
+type KafkaSink struct {
+	name   string
+	writer *kafka.Writer
+}
+
+// NewKafkaSink builds a Sink that produces to topic on the given Kafka
+// brokers.
+func NewKafkaSink(name string, brokers []string, topic string) *KafkaSink {
+	return &KafkaSink{
+		name: name,
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (s *KafkaSink) Name() string { return s.name }
+
+func (s *KafkaSink) Send(ctx context.Context, event cloudevents.Event) error {
+	body, err := event.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal CloudEvent")
+	}
+	err = s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Subject()),
+		Value: body,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to write CloudEvent to Kafka topic %s", s.writer.Topic)
+	}
+	return nil
+}
+
+// Close flushes and closes the underlying Kafka writer.
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
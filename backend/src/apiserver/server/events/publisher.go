@@ -0,0 +1,144 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events implements a pluggable CloudEvents (CNCF spec v1.0)
+// publishing subsystem used to notify external systems of run lifecycle
+// transitions without requiring them to poll the ListRuns API.
+package events
+
+import (
+	"context"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/golang/glog"
+)
+
+// Sink delivers a single CloudEvent to an external system (HTTP webhook,
+// Kafka topic, etc). Implementations should be safe for concurrent use.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+	// Send delivers event, returning an error if delivery failed. Publisher
+	// retries non-nil errors with backoff.
+	Send(ctx context.Context, event cloudevents.Event) error
+}
+
+// Publisher buffers CloudEvents and asynchronously fans them out to the
+// configured sinks, retrying failed deliveries with exponential backoff.
+// Namespace filtering keeps multi-user deployments from receiving events
+// for namespaces they do not own.
+type Publisher struct {
+	sinks      []Sink
+	queue      chan cloudevents.Event
+	namespaces NamespaceFilter
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+// NamespaceFilter decides whether an event for namespace should be
+// delivered to a given sink. A nil filter allows every namespace.
+type NamespaceFilter func(sinkName, namespace string) bool
+
+// Option configures a Publisher.
+type Option func(*Publisher)
+
+// WithNamespaceFilter restricts delivery so that each sink only receives
+// events for namespaces it is allowed to see.
+func WithNamespaceFilter(filter NamespaceFilter) Option {
+	return func(p *Publisher) { p.namespaces = filter }
+}
+
+// WithRetryPolicy overrides the default retry/backoff policy.
+func WithRetryPolicy(maxRetries int, baseDelay time.Duration) Option {
+	return func(p *Publisher) {
+		p.maxRetries = maxRetries
+		p.baseDelay = baseDelay
+	}
+}
+
+// NewPublisher creates a Publisher that delivers to sinks through a
+// bufferSize-deep async queue. Call Start to begin processing.
+func NewPublisher(sinks []Sink, bufferSize int, opts ...Option) *Publisher {
+	p := &Publisher{
+		sinks:      sinks,
+		queue:      make(chan cloudevents.Event, bufferSize),
+		maxRetries: 5,
+		baseDelay:  500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Start launches the delivery loop. It returns immediately; delivery
+// continues in the background until ctx is cancelled.
+func (p *Publisher) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-p.queue:
+				p.deliver(ctx, event)
+			}
+		}
+	}()
+}
+
+// Publish enqueues event for async delivery to every configured sink. It
+// never blocks the caller on network I/O; if the queue is full the event
+// is dropped and logged, since run lifecycle RPCs must not be delayed by
+// downstream event consumers.
+func (p *Publisher) Publish(event cloudevents.Event) {
+	select {
+	case p.queue <- event:
+	default:
+		glog.Errorf("Event queue full, dropping CloudEvent %s (type=%s)", event.ID(), event.Type())
+	}
+}
+
+func (p *Publisher) deliver(ctx context.Context, event cloudevents.Event) {
+	namespace := event.Extensions()["namespace"]
+	for _, sink := range p.sinks {
+		if p.namespaces != nil {
+			ns, _ := namespace.(string)
+			if !p.namespaces(sink.Name(), ns) {
+				continue
+			}
+		}
+		go p.deliverToSink(ctx, sink, event)
+	}
+}
+
+func (p *Publisher) deliverToSink(ctx context.Context, sink Sink, event cloudevents.Event) {
+	delay := p.baseDelay
+	var lastErr error
+	for attempt := 0; attempt <= p.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		if lastErr = sink.Send(ctx, event); lastErr == nil {
+			return
+		}
+		glog.Warningf("Failed to deliver CloudEvent %s to sink %s (attempt %d): %v", event.ID(), sink.Name(), attempt+1, lastErr)
+	}
+	glog.Errorf("Giving up delivering CloudEvent %s to sink %s after %d attempts: %v", event.ID(), sink.Name(), p.maxRetries+1, lastErr)
+}
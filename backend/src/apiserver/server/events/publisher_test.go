@@ -0,0 +1,136 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSink is a trivially fakeable Sink for exercising Publisher without a
+// real HTTP or Kafka endpoint.
+type fakeSink struct {
+	name string
+	err  error
+	// done, if non-nil, receives a value every time Send is called, so a
+	// test can synchronize with Publisher's delivery goroutines.
+	done chan struct{}
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeSink) Name() string { return f.name }
+
+func (f *fakeSink) Send(ctx context.Context, event cloudevents.Event) error {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+	if f.done != nil {
+		f.done <- struct{}{}
+	}
+	return f.err
+}
+
+func (f *fakeSink) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestPublishDropsEventWhenQueueIsFull(t *testing.T) {
+	p := NewPublisher(nil, 1)
+	first, err := NewRunEvent(TypeRunCreated, "run1", "ns1", nil)
+	assert.Nil(t, err)
+	second, err := NewRunEvent(TypeRunCreated, "run2", "ns1", nil)
+	assert.Nil(t, err)
+
+	// Publisher.Start is never called, so nothing ever drains the queue:
+	// the first Publish fills it, and the second must be dropped rather
+	// than block the caller.
+	p.Publish(first)
+	p.Publish(second)
+
+	queued := <-p.queue
+	assert.Equal(t, first.ID(), queued.ID())
+	select {
+	case <-p.queue:
+		t.Fatal("expected the second event to have been dropped, not queued behind the first")
+	default:
+	}
+}
+
+func TestDeliverToSinkGivesUpAfterMaxRetries(t *testing.T) {
+	sink := &fakeSink{name: "s1", err: errors.New("boom")}
+	p := NewPublisher(nil, 1, WithRetryPolicy(2, time.Millisecond))
+	event, err := NewRunEvent(TypeRunCreated, "run1", "ns1", nil)
+	assert.Nil(t, err)
+
+	p.deliverToSink(context.Background(), sink, event)
+
+	// The initial attempt plus 2 retries, then deliverToSink must give up
+	// rather than retry forever.
+	assert.Equal(t, 3, sink.Calls())
+}
+
+func TestDeliverToSinkStopsRetryingOnSuccess(t *testing.T) {
+	sink := &fakeSink{name: "s1"}
+	p := NewPublisher(nil, 1, WithRetryPolicy(5, time.Millisecond))
+	event, err := NewRunEvent(TypeRunCreated, "run1", "ns1", nil)
+	assert.Nil(t, err)
+
+	p.deliverToSink(context.Background(), sink, event)
+
+	assert.Equal(t, 1, sink.Calls())
+}
+
+func TestDeliverSuppressesNamespaceDisallowedForSink(t *testing.T) {
+	sink := &fakeSink{name: "s1"}
+	p := NewPublisher([]Sink{sink}, 1, WithNamespaceFilter(func(sinkName, namespace string) bool {
+		return namespace == "allowed-ns"
+	}))
+	event, err := NewRunEvent(TypeRunCreated, "run1", "blocked-ns", nil)
+	assert.Nil(t, err)
+
+	// deliver only spawns delivery goroutines for sinks the filter allows,
+	// so a disallowed namespace must leave the sink untouched.
+	p.deliver(context.Background(), event)
+
+	assert.Equal(t, 0, sink.Calls())
+}
+
+func TestDeliverDeliversAllowedNamespaceToSink(t *testing.T) {
+	sink := &fakeSink{name: "s1", done: make(chan struct{}, 1)}
+	p := NewPublisher([]Sink{sink}, 1, WithNamespaceFilter(func(sinkName, namespace string) bool {
+		return namespace == "allowed-ns"
+	}))
+	event, err := NewRunEvent(TypeRunCreated, "run1", "allowed-ns", nil)
+	assert.Nil(t, err)
+
+	p.deliver(context.Background(), event)
+
+	select {
+	case <-sink.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sink.Send was never called for an allowed namespace")
+	}
+	assert.Equal(t, 1, sink.Calls())
+}
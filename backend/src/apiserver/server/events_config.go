@@ -0,0 +1,87 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server/events"
+)
+
+const (
+	runEventsBufferSize = 1000
+)
+
+// NewRunEventPublisherOrFatal builds the run lifecycle event Publisher
+// from server configuration. It returns nil (publishing disabled) when
+// no sink is configured, which RunServer treats as a no-op.
+//
+// Recognized config keys:
+//
+//	RunEventsHTTPSinkURL     - if set, POST events to this URL
+//	RunEventsKafkaBrokers    - comma-separated list of brokers
+//	RunEventsKafkaTopic      - topic to publish to, required with brokers
+//	RunEventsAllowedNamespaces - comma-separated namespace allowlist;
+//	  empty means all namespaces are delivered
+func NewRunEventPublisherOrFatal() *events.Publisher {
+	var sinks []events.Sink
+
+	if httpURL := common.GetStringConfigWithDefault("RunEventsHTTPSinkURL", ""); httpURL != "" {
+		sink, err := events.NewHTTPSink("http", httpURL)
+		if err != nil {
+			glog.Fatalf("Failed to initialize run events HTTP sink: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if brokersConfig := common.GetStringConfigWithDefault("RunEventsKafkaBrokers", ""); brokersConfig != "" {
+		topic := common.GetStringConfigWithDefault("RunEventsKafkaTopic", "")
+		if topic == "" {
+			glog.Fatalf("RunEventsKafkaTopic must be set when RunEventsKafkaBrokers is configured")
+		}
+		brokers := strings.Split(brokersConfig, ",")
+		sinks = append(sinks, events.NewKafkaSink("kafka", brokers, topic))
+	}
+
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	allowlist := parseNamespaceAllowlist(common.GetStringConfigWithDefault("RunEventsAllowedNamespaces", ""))
+	publisher := events.NewPublisher(sinks, runEventsBufferSize, events.WithNamespaceFilter(
+		func(sinkName, namespace string) bool {
+			if len(allowlist) == 0 {
+				return true
+			}
+			return allowlist[namespace]
+		}))
+	return publisher
+}
+
+func parseNamespaceAllowlist(config string) map[string]bool {
+	if config == "" {
+		return nil
+	}
+	allowlist := make(map[string]bool)
+	for _, namespace := range strings.Split(config, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace != "" {
+			allowlist[namespace] = true
+		}
+	}
+	return allowlist
+}
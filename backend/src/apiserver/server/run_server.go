@@ -17,17 +17,27 @@ package server
 import (
 	"context"
 
+	"github.com/golang/glog"
 	"github.com/golang/protobuf/ptypes/empty"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server/artifact"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server/events"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/pkg/errors"
 )
 
 type RunServer struct {
 	resourceManager *resource.ResourceManager
+	// eventPublisher emits CloudEvents for run lifecycle transitions. It is
+	// nil when no event sinks are configured, in which case publishing is a
+	// no-op.
+	eventPublisher *events.Publisher
+	// uploadSessions tracks in-flight chunked artifact uploads so that
+	// StreamWriteArtifact can resume a session across multiple RPC calls.
+	uploadSessions *artifact.SessionStore
 }
 
 func (s *RunServer) CreateRun(ctx context.Context, request *api.CreateRunRequest) (*api.RunDetail, error) {
@@ -40,11 +50,24 @@ func (s *RunServer) CreateRun(ctx context.Context, request *api.CreateRunRequest
 		return nil, util.Wrap(err, "Failed to authorize the request.")
 	}
 
-	run, err := s.resourceManager.CreateRun(request.Run)
+	var run *model.Run
+	if request.Run.CustomTaskRef != nil {
+		// Custom tasks skip Argo Workflow compilation entirely: the
+		// resource manager records the run with its CustomTaskRef, and
+		// get, list, terminate and retry go through the same RunServer
+		// RPCs as for an Argo-backed run. No custom resource is created in
+		// the cluster and none is watched; GetRun and ListRuns refresh the
+		// run's condition through SyncCustomTaskRunStatus.
+		run, err = s.resourceManager.CreateCustomTaskRun(request.Run)
+	} else {
+		run, err = s.resourceManager.CreateRun(request.Run)
+	}
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to create a new run.")
 	}
-	return ToApiRunDetail(run), nil
+	runDetail := ToApiRunDetail(run)
+	s.publishRunEvent(events.TypeRunCreated, run.UUID, run.Namespace, runDetail)
+	return runDetail, nil
 }
 
 func (s *RunServer) GetRun(ctx context.Context, request *api.GetRunRequest) (*api.RunDetail, error) {
@@ -56,6 +79,9 @@ func (s *RunServer) GetRun(ctx context.Context, request *api.GetRunRequest) (*ap
 	if err != nil {
 		return nil, err
 	}
+	if err := s.resourceManager.SyncCustomTaskRunStatus(run); err != nil {
+		return nil, util.Wrap(err, "Failed to sync custom task run status.")
+	}
 	return ToApiRunDetail(run), nil
 }
 
@@ -103,6 +129,11 @@ func (s *RunServer) ListRuns(ctx context.Context, request *api.ListRunsRequest)
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to list runs.")
 	}
+	for _, run := range runs {
+		if err := s.resourceManager.SyncCustomTaskRunStatus(run); err != nil {
+			return nil, util.Wrap(err, "Failed to sync custom task run status.")
+		}
+	}
 	return &api.ListRunsResponse{Runs: ToApiRuns(runs), TotalSize: int32(total_size), NextPageToken: nextPageToken}, nil
 }
 
@@ -115,6 +146,7 @@ func (s *RunServer) ArchiveRun(ctx context.Context, request *api.ArchiveRunReque
 	if err != nil {
 		return nil, err
 	}
+	s.publishRunLifecycleEvent(events.TypeRunArchived, request.Id)
 	return &empty.Empty{}, nil
 }
 
@@ -127,6 +159,7 @@ func (s *RunServer) UnarchiveRun(ctx context.Context, request *api.UnarchiveRunR
 	if err != nil {
 		return nil, err
 	}
+	s.publishRunLifecycleEvent(events.TypeRunUnarchived, request.Id)
 	return &empty.Empty{}, nil
 }
 
@@ -135,10 +168,19 @@ func (s *RunServer) DeleteRun(ctx context.Context, request *api.DeleteRunRequest
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the request.")
 	}
+	// The run record (and with it, its namespace) is gone once DeleteRun
+	// returns, so the namespace must be resolved beforehand for the
+	// deleted-run event below; publishRunLifecycleEvent's own lookup would
+	// always fail after the fact.
+	namespace, err := s.resourceManager.GetNamespaceFromRunID(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to resolve namespace for run.")
+	}
 	err = s.resourceManager.DeleteRun(request.Id)
 	if err != nil {
 		return nil, err
 	}
+	s.publishRunEvent(events.TypeRunDeleted, request.Id, namespace, nil)
 	return &empty.Empty{}, nil
 }
 
@@ -160,6 +202,7 @@ func (s *RunServer) ReportRunMetrics(ctx context.Context, request *api.ReportRun
 			response.Results,
 			NewReportRunMetricResult(metric.GetName(), metric.GetNodeId(), err))
 	}
+	s.publishRunLifecycleEvent(events.TypeRunMetrics, request.GetRunId(), response)
 	return response, nil
 }
 
@@ -180,6 +223,10 @@ func (s *RunServer) validateCreateRunRequest(request *api.CreateRunRequest) erro
 		return util.NewInvalidInputError("The run name is empty. Please specify a valid name.")
 	}
 
+	if run.CustomTaskRef != nil {
+		return s.validateCustomTaskRef(run)
+	}
+
 	if err := ValidatePipelineSpec(s.resourceManager, run.PipelineSpec); err != nil {
 		if _, errResourceReference := CheckPipelineVersionReference(s.resourceManager, run.ResourceReferences); errResourceReference != nil {
 			return util.Wrap(err, "Neither pipeline spec nor pipeline version is valid. "+errResourceReference.Error())
@@ -189,15 +236,71 @@ func (s *RunServer) validateCreateRunRequest(request *api.CreateRunRequest) erro
 	return nil
 }
 
+// validateCustomTaskRef rejects runs that delegate to a controller that
+// the run's namespace has not whitelisted via the registration API, so
+// that a user cannot point a run at an arbitrary, possibly unsafe,
+// cluster-scoped controller. Custom task runs must carry a direct
+// Namespace resource reference: GetNamespaceFromResourceReferences has
+// no experiment store to fall back on, so an experiment-only reference
+// (the form ListRuns and CanAccessExperimentInResourceReferences accept)
+// is rejected here rather than silently resolved. The run's Namespace
+// must equal that referenced namespace, since the run is stored and
+// later re-checked under its Namespace.
+func (s *RunServer) validateCustomTaskRef(run *api.Run) error {
+	ref := run.CustomTaskRef
+	if ref.Kind == "" || ref.Name == "" {
+		return util.NewInvalidInputError("customTaskRef requires both kind and name to be specified.")
+	}
+	namespace, err := GetNamespaceFromResourceReferences(run.ResourceReferences)
+	if err != nil {
+		return util.NewInvalidInputError("customTaskRef requires a direct namespace resource reference: %v", err)
+	}
+	if run.GetNamespace() != namespace {
+		return util.NewInvalidInputError(
+			"customTaskRef run namespace %q does not match its namespace resource reference %q.", run.GetNamespace(), namespace)
+	}
+	registered, err := s.resourceManager.IsCustomTaskTypeRegistered(namespace, model.CustomTaskGroup(ref.ApiVersion), ref.Kind)
+	if err != nil {
+		return util.Wrap(err, "Failed to check customTaskRef registration.")
+	}
+	if !registered {
+		return util.NewInvalidInputError(
+			"customTaskRef {apiVersion: %s, kind: %s} is not registered for namespace %s.", ref.ApiVersion, ref.Kind, namespace)
+	}
+	return nil
+}
+
+// GetNamespaceFromResourceReferences extracts the namespace a direct
+// Namespace resource reference points at. It does not resolve an
+// experiment-only reference to the owning experiment's namespace —
+// doing so needs the experiment store, which callers of this helper
+// don't have access to — so it must only be used where a direct
+// namespace reference is required, not wherever ListRuns and
+// CanAccessExperimentInResourceReferences accept an experiment
+// reference instead.
+func GetNamespaceFromResourceReferences(refs []*api.ResourceReference) (string, error) {
+	for _, ref := range refs {
+		if ref.Key != nil && ref.Key.Type == common.Namespace {
+			return ref.Key.Id, nil
+		}
+	}
+	return "", util.NewInvalidInputError("No namespace resource reference found.")
+}
+
 func (s *RunServer) TerminateRun(ctx context.Context, request *api.TerminateRunRequest) (*empty.Empty, error) {
 	err := s.canAccessRun(ctx, request.RunId)
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the request.")
 	}
+	// For custom task runs, the resource manager routes termination
+	// through annotateCustomTaskRun instead of patching an Argo Workflow;
+	// that records the run's condition as "Terminating" and sets no
+	// annotation on any cluster object.
 	err = s.resourceManager.TerminateRun(request.RunId)
 	if err != nil {
 		return nil, err
 	}
+	s.publishRunLifecycleEvent(events.TypeRunTerminated, request.RunId)
 	return &empty.Empty{}, nil
 }
 
@@ -210,10 +313,55 @@ func (s *RunServer) RetryRun(ctx context.Context, request *api.RetryRunRequest)
 	if err != nil {
 		return nil, err
 	}
+	s.publishRunLifecycleEvent(events.TypeRunRetried, request.RunId)
 	return &empty.Empty{}, nil
 
 }
 
+// runPermissionVerbs are the canonical verbs GetRunPermissions reports
+// on, mirroring the RunServer RPCs a UI might render an action button
+// for.
+var runPermissionVerbs = []string{"get", "archive", "unarchive", "delete", "terminate", "retry", "reportMetrics", "readArtifact"}
+
+// GetRunPermissions reports, for every canonical run verb, whether the
+// caller may perform it on runId, so a UI can render action buttons
+// correctly without probing each endpoint and taking an error response
+// as "not allowed".
+func (s *RunServer) GetRunPermissions(ctx context.Context, request *api.GetRunPermissionsRequest) (*api.GetRunPermissionsResponse, error) {
+	namespace, err := s.resourceManager.GetNamespaceFromRunID(request.GetRunId())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to resolve namespace for run.")
+	}
+	if common.IsMultiUserMode() && len(namespace) == 0 {
+		return nil, util.NewInternalServerError(errors.New("There is no namespace found"), "There is no namespace found")
+	}
+
+	response := &api.GetRunPermissionsResponse{}
+	if !common.IsMultiUserMode() {
+		for _, verb := range runPermissionVerbs {
+			response.Permissions = append(response.Permissions, &api.GetRunPermissionsResponse_Permission{
+				Verb:    verb,
+				Allowed: true,
+			})
+		}
+		return response, nil
+	}
+
+	rules, err := s.resourceManager.GetCachedSelfSubjectRules(ctx, namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to evaluate run permissions.")
+	}
+	for _, verb := range runPermissionVerbs {
+		allowed, reason := rules.Can(verb)
+		response.Permissions = append(response.Permissions, &api.GetRunPermissionsResponse_Permission{
+			Verb:    verb,
+			Allowed: allowed,
+			Reason:  reason,
+		})
+	}
+	return response, nil
+}
+
 func (s *RunServer) canAccessRun(ctx context.Context, runId string) error {
 	if common.IsMultiUserMode() == false {
 		// Skip authz if not multi-user mode.
@@ -234,6 +382,46 @@ func (s *RunServer) canAccessRun(ctx context.Context, runId string) error {
 	return nil
 }
 
-func NewRunServer(resourceManager *resource.ResourceManager) *RunServer {
-	return &RunServer{resourceManager: resourceManager}
+// publishRunEvent builds and asynchronously publishes a CloudEvent for a
+// run lifecycle transition. It is a no-op when no event publisher is
+// configured. Errors building the event are logged rather than returned,
+// since a downstream event-consumer problem must never fail the RPC.
+func (s *RunServer) publishRunEvent(eventType, runID, namespace string, data interface{}) {
+	if s.eventPublisher == nil {
+		return
+	}
+	event, err := events.NewRunEvent(eventType, runID, namespace, data)
+	if err != nil {
+		glog.Errorf("Failed to build CloudEvent %s for run %s: %v", eventType, runID, err)
+		return
+	}
+	s.eventPublisher.Publish(event)
+}
+
+// publishRunLifecycleEvent is a convenience wrapper around publishRunEvent
+// for RPCs that only have the run ID on hand; it looks up the owning
+// namespace so per-namespace sink filtering still applies. data is
+// optional and defaults to nil when the caller has no payload to attach.
+func (s *RunServer) publishRunLifecycleEvent(eventType, runID string, data ...interface{}) {
+	if s.eventPublisher == nil {
+		return
+	}
+	namespace, err := s.resourceManager.GetNamespaceFromRunID(runID)
+	if err != nil {
+		glog.Errorf("Failed to resolve namespace for run %s while publishing %s: %v", runID, eventType, err)
+		return
+	}
+	var payload interface{}
+	if len(data) > 0 {
+		payload = data[0]
+	}
+	s.publishRunEvent(eventType, runID, namespace, payload)
+}
+
+func NewRunServer(resourceManager *resource.ResourceManager, eventPublisher *events.Publisher) *RunServer {
+	return &RunServer{
+		resourceManager: resourceManager,
+		eventPublisher:  eventPublisher,
+		uploadSessions:  artifact.NewSessionStore(),
+	}
 }
@@ -0,0 +1,45 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNamespaceFromResourceReferences(t *testing.T) {
+	namespace, err := GetNamespaceFromResourceReferences([]*api.ResourceReference{
+		{Key: &api.ResourceKey{Type: common.Experiment, Id: "exp1"}},
+		{Key: &api.ResourceKey{Type: common.Namespace, Id: "ns1"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "ns1", namespace)
+
+	_, err = GetNamespaceFromResourceReferences(nil)
+	assert.NotNil(t, err)
+}
+
+func TestGetNamespaceFromResourceReferences_NilKey(t *testing.T) {
+	// A ResourceReference with an unset Key is a normal, client-controllable
+	// shape (e.g. a malformed CreateRun request) and must be skipped rather
+	// than dereferenced.
+	_, err := GetNamespaceFromResourceReferences([]*api.ResourceReference{
+		{Key: nil},
+	})
+	assert.NotNil(t, err)
+}